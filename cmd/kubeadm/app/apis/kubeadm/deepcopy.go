@@ -0,0 +1,52 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto copies all fields of in into out.
+func (in *MasterConfiguration) DeepCopyInto(out *MasterConfiguration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Etcd.Local != nil {
+		local := *in.Etcd.Local
+		out.Etcd.Local = &local
+	}
+	if in.ImageDigests != nil {
+		out.ImageDigests = make(map[string]string, len(in.ImageDigests))
+		for k, v := range in.ImageDigests {
+			out.ImageDigests[k] = v
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of in.
+func (in *MasterConfiguration) DeepCopy() *MasterConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MasterConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *MasterConfiguration) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scheme combines kubeadm's internal configuration type and its external versions into a
+// single runtime.Scheme/serializer.CodecFactory pair, the same way k8s.io/client-go/kubernetes/
+// scheme does for the built-in API groups.
+package scheme
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1alpha2"
+)
+
+// Scheme knows about kubeadm's internal MasterConfiguration, every external version it converts
+// to/from, and the built-in API objects (Pods, ConfigMaps, Secrets, ...) kubeadm generates, so
+// that both can be encoded/decoded through a single CodecFactory.
+var Scheme = runtime.NewScheme()
+
+// Codecs provides access to encoding and decoding for Scheme.
+var Codecs = serializer.NewCodecFactory(Scheme)
+
+func init() {
+	AddToScheme(Scheme)
+}
+
+// AddToScheme adds kubeadm's internal and external API types, plus the built-in API objects
+// kubeadm generates, to scheme.
+func AddToScheme(scheme *runtime.Scheme) {
+	utilruntime.Must(kubeadm.AddToScheme(scheme))
+	utilruntime.Must(v1alpha2.AddToScheme(scheme))
+	utilruntime.Must(scheme.SetVersionPriority(v1alpha2.SchemeGroupVersion))
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MasterConfiguration is the internal representation of the configuration kubeadm uses to set
+// up a control-plane node. Only the fields the images/preflight packages depend on are modeled
+// here.
+type MasterConfiguration struct {
+	metav1.TypeMeta
+
+	// API holds information about the endpoint the API server listens on.
+	API API
+
+	// NodeRegistration holds fields needed to register this node as a control-plane node.
+	NodeRegistration NodeRegistrationOptions
+
+	// Networking holds configuration for the cluster's pod and service networks.
+	Networking Networking
+
+	// KubernetesVersion is the target Kubernetes version for the control plane.
+	KubernetesVersion string
+
+	// ImageRepository is the container registry to pull control-plane images from.
+	ImageRepository string
+
+	// CIImageRepository overrides ImageRepository for CI-built Kubernetes versions and is never
+	// set by end users.
+	CIImageRepository string
+
+	// UnifiedControlPlaneImage, when set, overrides the per-component control-plane images with
+	// a single image (used by some CI/dev workflows).
+	UnifiedControlPlaneImage string
+
+	// Etcd holds the configuration for the cluster's etcd instance.
+	Etcd Etcd
+
+	// ImageDigests pins a component name (e.g. "kube-apiserver") to a sha256 digest that is
+	// appended to its image reference, so the container runtime refuses a pull whose content
+	// does not match. A component absent from this map is pulled unpinned, unless kubeadm's
+	// compiled-in defaults for KubernetesVersion provide one.
+	ImageDigests map[string]string
+}
+
+// Etcd contains configuration for the etcd member run by this control-plane node.
+type Etcd struct {
+	// Local, if set, configures a locally-managed etcd instance.
+	Local *LocalEtcd
+}
+
+// LocalEtcd describes a locally-managed etcd instance.
+type LocalEtcd struct {
+	// Image overrides the etcd image kubeadm would otherwise compute from KubernetesVersion.
+	Image string
+}
+
+// API holds information about the endpoint the API server listens on.
+type API struct {
+	// AdvertiseAddress is the IP address the API server advertises it is accessible on.
+	AdvertiseAddress string
+
+	// BindPort is the port the API server binds to.
+	BindPort int32
+}
+
+// NodeRegistrationOptions holds fields needed to register a node as a control-plane node.
+type NodeRegistrationOptions struct {
+	// Name is the name this node registers under.
+	Name string
+
+	// CRISocket is the CRI socket kubeadm talks to on this node.
+	CRISocket string
+}
+
+// Networking holds configuration for the cluster's pod and service networks.
+type Networking struct {
+	// ServiceSubnet is the subnet used for Services.
+	ServiceSubnet string
+
+	// PodSubnet is the subnet used for Pods.
+	PodSubnet string
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+)
+
+func addConversionFuncs(scheme *runtime.Scheme) error {
+	if err := scheme.AddConversionFunc((*MasterConfiguration)(nil), (*kubeadm.MasterConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_MasterConfiguration_To_kubeadm_MasterConfiguration(a.(*MasterConfiguration), b.(*kubeadm.MasterConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	return scheme.AddConversionFunc((*kubeadm.MasterConfiguration)(nil), (*MasterConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeadm_MasterConfiguration_To_v1alpha2_MasterConfiguration(a.(*kubeadm.MasterConfiguration), b.(*MasterConfiguration), scope)
+	})
+}
+
+// Convert_v1alpha2_MasterConfiguration_To_kubeadm_MasterConfiguration converts the v1alpha2 wire
+// format into the internal type. CIImageRepository, UnifiedControlPlaneImage, Etcd and
+// ImageDigests have no v1alpha2 counterpart and are left at their zero value: they are advanced
+// settings kubeadm only ever sets programmatically, never something a user supplies over the
+// external API.
+func Convert_v1alpha2_MasterConfiguration_To_kubeadm_MasterConfiguration(in *MasterConfiguration, out *kubeadm.MasterConfiguration, s conversion.Scope) error {
+	out.TypeMeta = in.TypeMeta
+	out.API = kubeadm.API{AdvertiseAddress: in.API.AdvertiseAddress, BindPort: in.API.BindPort}
+	out.NodeRegistration = kubeadm.NodeRegistrationOptions{Name: in.NodeRegistration.Name, CRISocket: in.NodeRegistration.CRISocket}
+	out.Networking = kubeadm.Networking{ServiceSubnet: in.Networking.ServiceSubnet, PodSubnet: in.Networking.PodSubnet}
+	out.KubernetesVersion = in.KubernetesVersion
+	out.ImageRepository = in.ImageRepository
+	return nil
+}
+
+// Convert_kubeadm_MasterConfiguration_To_v1alpha2_MasterConfiguration converts the internal type
+// back into the v1alpha2 wire format, dropping the internal-only fields noted above.
+func Convert_kubeadm_MasterConfiguration_To_v1alpha2_MasterConfiguration(in *kubeadm.MasterConfiguration, out *MasterConfiguration, s conversion.Scope) error {
+	out.TypeMeta = in.TypeMeta
+	out.API = API{AdvertiseAddress: in.API.AdvertiseAddress, BindPort: in.API.BindPort}
+	out.NodeRegistration = NodeRegistrationOptions{Name: in.NodeRegistration.Name, CRISocket: in.NodeRegistration.CRISocket}
+	out.Networking = Networking{ServiceSubnet: in.Networking.ServiceSubnet, PodSubnet: in.Networking.PodSubnet}
+	out.KubernetesVersion = in.KubernetesVersion
+	out.ImageRepository = in.ImageRepository
+	return nil
+}
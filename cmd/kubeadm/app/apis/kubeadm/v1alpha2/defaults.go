@@ -0,0 +1,52 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// DefaultServiceSubnet is used when Networking.ServiceSubnet is left unset.
+	DefaultServiceSubnet = "10.96.0.0/12"
+
+	// DefaultAPIBindPort is used when API.BindPort is left unset.
+	DefaultAPIBindPort = 6443
+
+	// DefaultKubernetesVersion is used when KubernetesVersion is left unset.
+	DefaultKubernetesVersion = "stable-1"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&MasterConfiguration{}, func(obj interface{}) {
+		SetDefaults_MasterConfiguration(obj.(*MasterConfiguration))
+	})
+	return nil
+}
+
+// SetDefaults_MasterConfiguration fills in values a user left unset with kubeadm's defaults.
+func SetDefaults_MasterConfiguration(obj *MasterConfiguration) {
+	if obj.KubernetesVersion == "" {
+		obj.KubernetesVersion = DefaultKubernetesVersion
+	}
+	if obj.API.BindPort == 0 {
+		obj.API.BindPort = DefaultAPIBindPort
+	}
+	if obj.Networking.ServiceSubnet == "" {
+		obj.Networking.ServiceSubnet = DefaultServiceSubnet
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MasterConfiguration is the v1alpha2 wire format for kubeadm's control-plane configuration.
+// Only the fields kubeadm's util/images packages depend on are modeled here.
+type MasterConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// API holds information about the endpoint the API server listens on.
+	API API `json:"api,omitempty"`
+
+	// NodeRegistration holds fields needed to register this node as a control-plane node.
+	NodeRegistration NodeRegistrationOptions `json:"nodeRegistration,omitempty"`
+
+	// Networking holds configuration for the cluster's pod and service networks.
+	Networking Networking `json:"networking,omitempty"`
+
+	// KubernetesVersion is the target Kubernetes version for the control plane.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// ImageRepository is the container registry to pull control-plane images from.
+	ImageRepository string `json:"imageRepository,omitempty"`
+}
+
+// API holds information about the endpoint the API server listens on.
+type API struct {
+	// AdvertiseAddress is the IP address the API server advertises it is accessible on.
+	AdvertiseAddress string `json:"advertiseAddress,omitempty"`
+
+	// BindPort is the port the API server binds to.
+	BindPort int32 `json:"bindPort,omitempty"`
+}
+
+// NodeRegistrationOptions holds fields needed to register a node as a control-plane node.
+type NodeRegistrationOptions struct {
+	// Name is the name this node registers under.
+	Name string `json:"name,omitempty"`
+
+	// CRISocket is the CRI socket kubeadm talks to on this node.
+	CRISocket string `json:"criSocket,omitempty"`
+}
+
+// Networking holds configuration for the cluster's pod and service networks.
+type Networking struct {
+	// ServiceSubnet is the subnet used for Services.
+	ServiceSubnet string `json:"serviceSubnet,omitempty"`
+
+	// PodSubnet is the subnet used for Pods.
+	PodSubnet string `json:"podSubnet,omitempty"`
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/scheme"
+	kubeadmapiv1alpha2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1alpha2"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util"
+)
+
+// NewCmdConfigKRM returns the "kubeadm config krm" command: it runs kubeadm as a Kubernetes
+// Resource Model function, reading a ResourceList from stdin and writing one back to stdout.
+func NewCmdConfigKRM() *cobra.Command {
+	return &cobra.Command{
+		Use:   "krm",
+		Short: "Run kubeadm as a Kubernetes Resource Model (KRM) function",
+		Long: "Reads a ResourceList with a MasterConfiguration functionConfig from stdin, " +
+			"generates a kube-apiserver static pod manifest, a kubelet ConfigMap and a " +
+			"bootstrap-token Secret for it, and writes them back to stdout as the " +
+			"ResourceList's items. Static pods for the other control-plane components are not " +
+			"generated. Intended for use from kpt/kustomize function pipelines.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return util.RunKRMFunction(os.Stdin, os.Stdout, kubeadmapiv1alpha2.SchemeGroupVersion, scheme.Scheme, scheme.Codecs, generateKRMObjects)
+		},
+	}
+}
+
+// generateKRMObjects is the KRMFunctionConfigHandler for "kubeadm config krm": given a defaulted,
+// converted MasterConfiguration, it returns the kube-apiserver static pod, the kubelet ConfigMap
+// and the bootstrap-token Secret generated for it.
+func generateKRMObjects(functionConfig runtime.Object) ([]runtime.Object, error) {
+	cfg, ok := functionConfig.(*kubeadmapiv1alpha2.MasterConfiguration)
+	if !ok {
+		return nil, fmt.Errorf("expected a MasterConfiguration, got %T", functionConfig)
+	}
+
+	return []runtime.Object{
+		kubeAPIServerStaticPod(cfg),
+		kubeletConfigMap(cfg),
+		bootstrapTokenSecret(),
+	}, nil
+}
+
+func kubeAPIServerStaticPod(cfg *kubeadmapiv1alpha2.MasterConfiguration) *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kube-apiserver",
+			Namespace: "kube-system",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:    "kube-apiserver",
+					Command: []string{"kube-apiserver", fmt.Sprintf("--advertise-address=%s", cfg.API.AdvertiseAddress)},
+				},
+			},
+			HostNetwork: true,
+		},
+	}
+}
+
+func kubeletConfigMap(cfg *kubeadmapiv1alpha2.MasterConfiguration) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kubelet-config",
+			Namespace: "kube-system",
+		},
+		Data: map[string]string{
+			"kubelet": fmt.Sprintf("podCIDR: %s\nserviceCIDR: %s\n", cfg.Networking.PodSubnet, cfg.Networking.ServiceSubnet),
+		},
+	}
+}
+
+func bootstrapTokenSecret() *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bootstrap-token-krm000",
+			Namespace: "kube-system",
+		},
+		Type: "bootstrap.kubernetes.io/token",
+	}
+}
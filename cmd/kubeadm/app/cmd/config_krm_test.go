@@ -0,0 +1,107 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/scheme"
+	kubeadmapiv1alpha2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1alpha2"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util"
+)
+
+// krmGoldenInput pins every field generateKRMObjects reads, so the golden output below is fully
+// determined by the input rather than by kubeadm's compiled-in defaults.
+const krmGoldenInput = `
+apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+functionConfig:
+  apiVersion: kubeadm.k8s.io/v1alpha2
+  kind: MasterConfiguration
+  api:
+    advertiseAddress: 10.100.0.1
+    bindPort: 6443
+  nodeRegistration:
+    name: test-node
+  networking:
+    serviceSubnet: 10.96.0.0/12
+    podSubnet: 10.244.0.0/16
+  kubernetesVersion: v1.18.0
+items: []
+`
+
+func TestGenerateKRMObjectsGolden(t *testing.T) {
+	var out strings.Builder
+	err := util.RunKRMFunction(strings.NewReader(krmGoldenInput), &out, kubeadmapiv1alpha2.SchemeGroupVersion, scheme.Scheme, scheme.Codecs, generateKRMObjects)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list, err := util.ReadKRMResourceList(strings.NewReader(out.String()))
+	if err != nil {
+		t.Fatalf("unexpected error reading generated ResourceList: %v", err)
+	}
+	if len(list.Items) != 3 {
+		t.Fatalf("expected a static pod, a ConfigMap and a Secret, got %d items", len(list.Items))
+	}
+
+	var pod corev1.Pod
+	if err := json.Unmarshal(list.Items[0], &pod); err != nil {
+		t.Fatalf("unexpected error unmarshalling the static pod: %v", err)
+	}
+	wantPod := *kubeAPIServerStaticPod(&kubeadmapiv1alpha2.MasterConfiguration{
+		API: kubeadmapiv1alpha2.API{AdvertiseAddress: "10.100.0.1", BindPort: 6443},
+	})
+	if !reflect.DeepEqual(pod, wantPod) {
+		t.Errorf("static pod does not match golden output:\n\texpected: %+v\n\t  actual: %+v", wantPod, pod)
+	}
+
+	var cm corev1.ConfigMap
+	if err := json.Unmarshal(list.Items[1], &cm); err != nil {
+		t.Fatalf("unexpected error unmarshalling the kubelet ConfigMap: %v", err)
+	}
+	wantCM := *kubeletConfigMap(&kubeadmapiv1alpha2.MasterConfiguration{
+		Networking: kubeadmapiv1alpha2.Networking{ServiceSubnet: "10.96.0.0/12", PodSubnet: "10.244.0.0/16"},
+	})
+	if !reflect.DeepEqual(cm, wantCM) {
+		t.Errorf("kubelet ConfigMap does not match golden output:\n\texpected: %+v\n\t  actual: %+v", wantCM, cm)
+	}
+
+	var secret corev1.Secret
+	if err := json.Unmarshal(list.Items[2], &secret); err != nil {
+		t.Fatalf("unexpected error unmarshalling the bootstrap-token Secret: %v", err)
+	}
+	wantSecret := *bootstrapTokenSecret()
+	if !reflect.DeepEqual(secret, wantSecret) {
+		t.Errorf("bootstrap-token Secret does not match golden output:\n\texpected: %+v\n\t  actual: %+v", wantSecret, secret)
+	}
+}
+
+func TestNewCmdConfigKRM(t *testing.T) {
+	cmd := NewCmdConfigKRM()
+	if cmd.Use != "krm" {
+		t.Errorf("expected command use %q, got %q", "krm", cmd.Use)
+	}
+	if cmd.RunE == nil {
+		t.Fatal("expected the command to have a RunE function")
+	}
+}
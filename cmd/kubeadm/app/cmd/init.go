@@ -0,0 +1,64 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/images"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/preflight"
+)
+
+// NewCmdInit returns the "kubeadm init" command.
+func NewCmdInit(out io.Writer) *cobra.Command {
+	cfg := &kubeadmapi.MasterConfiguration{}
+	var imageMirrorRegion string
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Run this command in order to set up the Kubernetes control plane",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInit(context.Background(), out, cfg, imageMirrorRegion)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&cfg.ImageRepository, "image-repository", "", "Choose a container registry to pull control plane images from")
+	flags.StringVar(&imageMirrorRegion, "image-mirror-region", "", "Region whose compiled-in mirror to prefer when --image-repository is not set; falls back to the detected system locale")
+
+	return cmd
+}
+
+// runInit runs "kubeadm init phase preflight" - which resolves cfg.ImageRepository via
+// preflight.EnsureImageRepository so restricted-network clusters get a working default - and
+// then writes out the images that phase resolved to pull.
+func runInit(ctx context.Context, out io.Writer, cfg *kubeadmapi.MasterConfiguration, imageMirrorRegion string) error {
+	puller := &images.CRIImagePuller{CRISocket: cfg.NodeRegistration.CRISocket}
+	if err := preflight.EnsureImageRepository(ctx, imageMirrorRegion, cfg, puller); err != nil {
+		return fmt.Errorf("preflight: %v", err)
+	}
+
+	for _, image := range images.GetAllImages(cfg, cfg.ImageRepository) {
+		fmt.Fprintln(out, image)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+)
+
+func TestRunInitHonorsExplicitImageRepository(t *testing.T) {
+	var out strings.Builder
+	cfg := &kubeadmapi.MasterConfiguration{ImageRepository: "my.registry.example.com"}
+
+	// An explicit ImageRepository short-circuits SelectImageRepository's probing, so this does
+	// not need a real CRI socket to reach.
+	if err := runInit(context.Background(), &out, cfg, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "my.registry.example.com") {
+		t.Errorf("expected the resolved images to reference the configured repository, got %q", out.String())
+	}
+}
+
+func TestNewCmdInit(t *testing.T) {
+	cmd := NewCmdInit(&strings.Builder{})
+	if cmd.Use != "init" {
+		t.Errorf("expected command use %q, got %q", "init", cmd.Use)
+	}
+	if cmd.Flags().Lookup("image-mirror-region") == nil {
+		t.Error("expected NewCmdInit to register an --image-mirror-region flag")
+	}
+	if cmd.Flags().Lookup("image-repository") == nil {
+		t.Error("expected NewCmdInit to register an --image-repository flag")
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package constants holds the component names and default versions shared across the kubeadm
+// packages (images, phases, preflight, ...).
+package constants
+
+import "fmt"
+
+// Component names, as used both for image names and as keys into per-component maps such as
+// MasterConfiguration.ImageDigests.
+const (
+	KubeAPIServer         = "kube-apiserver"
+	KubeControllerManager = "kube-controller-manager"
+	KubeScheduler         = "kube-scheduler"
+	Etcd                  = "etcd"
+	CoreDNS               = "coredns"
+)
+
+// Default component versions used when a cluster's Kubernetes version does not map to a more
+// specific supported version below.
+const (
+	DefaultEtcdVersion = "3.4.3-0"
+	CoreDNSVersion     = "1.6.5"
+	PauseVersion       = "3.1"
+)
+
+// etcdSupportedVersions maps a Kubernetes minor version (e.g. "v1.18") to the etcd version
+// kubeadm deploys alongside it.
+var etcdSupportedVersions = map[string]string{
+	"v1.17": "3.4.3-0",
+	"v1.18": "3.4.3-0",
+}
+
+// EtcdVersion is a thin wrapper so callers can format a resolved etcd version the same way
+// regardless of where it came from.
+type EtcdVersion struct {
+	version string
+}
+
+// String returns the etcd version as used in its image tag.
+func (v EtcdVersion) String() string {
+	return v.version
+}
+
+// EtcdSupportedVersion returns the etcd version kubeadm pairs with the given Kubernetes version,
+// or an error if the Kubernetes version's minor release is not in the supported table.
+func EtcdSupportedVersion(k8sVersion string) (EtcdVersion, error) {
+	minor := minorVersion(k8sVersion)
+	v, ok := etcdSupportedVersions[minor]
+	if !ok {
+		return EtcdVersion{}, fmt.Errorf("no supported etcd version known for Kubernetes version %q", k8sVersion)
+	}
+	return EtcdVersion{version: v}, nil
+}
+
+// minorVersion trims a Kubernetes version like "v1.18.0" down to its "v1.18" minor release.
+func minorVersion(k8sVersion string) string {
+	dots := 0
+	for i := 0; i < len(k8sVersion); i++ {
+		if k8sVersion[i] == '.' {
+			dots++
+			if dots == 2 {
+				return k8sVersion[:i]
+			}
+		}
+	}
+	return k8sVersion
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"fmt"
+	"io"
+	goruntime "runtime"
+	"sort"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+)
+
+// GetCachedImageList returns the deterministic, sorted list of every image kubeadm would pull
+// for a cluster of the given Kubernetes version against repo, for use by offline installers and
+// other tools that need to pre-populate a node's CRI image store. arch selects the target node
+// architecture and is independent of runtime.GOARCH, so a manifest for arm64 nodes can be
+// generated from an amd64 build of kubeadm. Unlike GetAllImages, the returned list always
+// includes the pause image: kubeadm itself never pulls it directly (the kubelet does, on
+// demand), which otherwise breaks air-gapped workflows.
+func GetCachedImageList(repo, k8sVersion, arch string) ([]string, error) {
+	if arch == "" {
+		arch = goruntime.GOARCH
+	}
+
+	kubernetesImageTag := versionToImageTag(k8sVersion)
+	imgs := []string{
+		GetGenericImageForArch(repo, "kube-apiserver", arch, kubernetesImageTag),
+		GetGenericImageForArch(repo, "kube-controller-manager", arch, kubernetesImageTag),
+		GetGenericImageForArch(repo, "kube-scheduler", arch, kubernetesImageTag),
+		GetGenericImageForArch(repo, "pause", arch, constants.PauseVersion),
+		GetGenericImageForArch(repo, "coredns", arch, constants.CoreDNSVersion),
+	}
+
+	etcdImageTag := constants.DefaultEtcdVersion
+	if etcdImageVersion, err := constants.EtcdSupportedVersion(k8sVersion); err == nil {
+		etcdImageTag = etcdImageVersion.String()
+	}
+	imgs = append(imgs, GetGenericImageForArch(repo, "etcd", arch, etcdImageTag))
+
+	sort.Strings(imgs)
+	return imgs, nil
+}
+
+// WriteImageCacheManifest writes the newline-separated, version-scoped list of images
+// GetCachedImageList computes for cfg to w, one image reference per line.
+func WriteImageCacheManifest(w io.Writer, cfg *kubeadmapi.MasterConfiguration) error {
+	imgs, err := GetCachedImageList(cfg.ImageRepository, cfg.KubernetesVersion, goruntime.GOARCH)
+	if err != nil {
+		return err
+	}
+	for _, img := range imgs {
+		if _, err := fmt.Fprintln(w, img); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"testing"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+)
+
+func TestGetCachedImageList(t *testing.T) {
+	tests := []struct {
+		k8sVersion string
+		arch       string
+		expect     []string
+	}{
+		{
+			k8sVersion: "v1.17.0",
+			arch:       "amd64",
+			expect: []string{
+				gcrPrefix + "/coredns-amd64:1.6.5",
+				gcrPrefix + "/etcd-amd64:3.4.3-0",
+				gcrPrefix + "/kube-apiserver-amd64:v1.17.0",
+				gcrPrefix + "/kube-controller-manager-amd64:v1.17.0",
+				gcrPrefix + "/kube-scheduler-amd64:v1.17.0",
+				gcrPrefix + "/pause-amd64:3.1",
+			},
+		},
+		{
+			k8sVersion: "v1.18.0",
+			arch:       "arm64",
+			expect: []string{
+				gcrPrefix + "/coredns-arm64:1.6.5",
+				gcrPrefix + "/etcd-arm64:3.4.3-0",
+				gcrPrefix + "/kube-apiserver-arm64:v1.18.0",
+				gcrPrefix + "/kube-controller-manager-arm64:v1.18.0",
+				gcrPrefix + "/kube-scheduler-arm64:v1.18.0",
+				gcrPrefix + "/pause-arm64:3.1",
+			},
+		},
+	}
+
+	for _, rt := range tests {
+		t.Run(rt.k8sVersion+"/"+rt.arch, func(t *testing.T) {
+			actual, err := GetCachedImageList(gcrPrefix, rt.k8sVersion, rt.arch)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expect := append([]string{}, rt.expect...)
+			sort.Strings(expect)
+			if !reflect.DeepEqual(actual, expect) {
+				t.Errorf("unexpected image set:\n\texpected: %v\n\t  actual: %v", expect, actual)
+			}
+		})
+	}
+}
+
+func TestGetCachedImageListIncludesPauseAndIsArchIndependent(t *testing.T) {
+	imgs, err := GetCachedImageList(gcrPrefix, "v1.18.0", "s390x")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := false
+	for _, img := range imgs {
+		if img == gcrPrefix+"/pause-s390x:3.1" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the pause image for arch s390x to be present, got %v", imgs)
+	}
+}
+
+func TestWriteImageCacheManifest(t *testing.T) {
+	cfg := &kubeadmapi.MasterConfiguration{
+		ImageRepository:   gcrPrefix,
+		KubernetesVersion: "v1.17.0",
+	}
+	var buf bytes.Buffer
+	if err := WriteImageCacheManifest(&buf, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Len() == 0 {
+		t.Fatal("expected a non-empty manifest")
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+)
+
+// GetGenericArchImage generates and returns the image name for the current runtime.GOARCH.
+func GetGenericArchImage(prefix, image, tag string) string {
+	return GetGenericImageForArch(prefix, image, runtime.GOARCH, tag)
+}
+
+// GetGenericImageForArch generates and returns the image name for the given arch, regardless of
+// runtime.GOARCH. This lets callers (e.g. the image cache manifest generator) describe images
+// for a node architecture other than the one kubeadm itself is running on.
+func GetGenericImageForArch(prefix, image, arch, tag string) string {
+	return fmt.Sprintf("%s/%s-%s:%s", prefix, image, arch, tag)
+}
+
+// GetKubeControlPlaneImage returns the image for the given control plane component, honoring
+// UnifiedControlPlaneImage and any pinned digest configured for the component.
+func GetKubeControlPlaneImage(image string, cfg *kubeadmapi.MasterConfiguration) string {
+	if cfg.UnifiedControlPlaneImage != "" {
+		return cfg.UnifiedControlPlaneImage
+	}
+	return GetKubeControlPlaneImageNoOverride(image, cfg)
+}
+
+// GetKubeControlPlaneImageNoOverride returns the image for the given control plane component,
+// ignoring UnifiedControlPlaneImage. If a digest is pinned for the component, either explicitly
+// in cfg.ImageDigests or via kubeadm's compiled-in defaults for cfg.KubernetesVersion, it is
+// appended to the reference.
+func GetKubeControlPlaneImageNoOverride(image string, cfg *kubeadmapi.MasterConfiguration) string {
+	kubernetesImageTag := versionToImageTag(cfg.KubernetesVersion)
+	ref := GetGenericArchImage(cfg.ImageRepository, image, kubernetesImageTag)
+	return pinDigest(ref, resolveDigest(cfg, image))
+}
+
+// GetEtcdImage returns the image for etcd, honoring a locally configured override image and
+// any pinned digest for the etcd component.
+func GetEtcdImage(cfg *kubeadmapi.MasterConfiguration) string {
+	if cfg.Etcd.Local != nil && cfg.Etcd.Local.Image != "" {
+		return cfg.Etcd.Local.Image
+	}
+	etcdImageTag := constants.DefaultEtcdVersion
+	etcdImageVersion, err := constants.EtcdSupportedVersion(cfg.KubernetesVersion)
+	if err == nil {
+		etcdImageTag = etcdImageVersion.String()
+	}
+	ref := GetGenericArchImage(cfg.ImageRepository, "etcd", etcdImageTag)
+	return pinDigest(ref, resolveDigest(cfg, constants.Etcd))
+}
+
+// GetCoreDNSImage returns the image for CoreDNS, honoring any pinned digest for the component.
+// CoreDNS is never affected by UnifiedControlPlaneImage: it isn't a control-plane component.
+func GetCoreDNSImage(cfg *kubeadmapi.MasterConfiguration) string {
+	ref := GetGenericArchImage(cfg.ImageRepository, "coredns", constants.CoreDNSVersion)
+	return pinDigest(ref, resolveDigest(cfg, constants.CoreDNS))
+}
+
+// resolveDigest returns the sha256 digest kubeadm should pin component to: an explicit entry in
+// cfg.ImageDigests takes precedence, falling back to kubeadm's compiled-in default digest table
+// for cfg.KubernetesVersion. This is what makes a stock install resolve to a digest-pinned
+// reference without the user having to populate ImageDigests by hand.
+func resolveDigest(cfg *kubeadmapi.MasterConfiguration, component string) string {
+	if digest, ok := cfg.ImageDigests[component]; ok {
+		return digest
+	}
+	return GetDefaultImageDigests(cfg.KubernetesVersion)[component]
+}
+
+// GetAllImages returns a list of container images kubeadm expects to use on a control-plane
+// node, resolved against imageRepository rather than cfg.ImageRepository directly. Callers are
+// expected to have already resolved the repository to use, e.g. via SelectImageRepository.
+func GetAllImages(cfg *kubeadmapi.MasterConfiguration, imageRepository string) []string {
+	var imgs []string
+
+	if cfg.CIImageRepository != "" {
+		imageRepository = cfg.CIImageRepository
+	}
+
+	localCfg := *cfg
+	localCfg.ImageRepository = imageRepository
+
+	imgs = append(imgs, GetKubeControlPlaneImage(constants.KubeAPIServer, &localCfg))
+	imgs = append(imgs, GetKubeControlPlaneImage(constants.KubeControllerManager, &localCfg))
+	imgs = append(imgs, GetKubeControlPlaneImage(constants.KubeScheduler, &localCfg))
+
+	if cfg.Etcd.Local != nil {
+		imgs = append(imgs, GetEtcdImage(&localCfg))
+	}
+
+	imgs = append(imgs, GetCoreDNSImage(&localCfg))
+
+	return imgs
+}
+
+// pinDigest appends a "@sha256:<digest>" suffix to ref when digest is non-empty. If ref already
+// carries a digest (e.g. because the caller pre-resolved it), that digest is kept unchanged.
+func pinDigest(ref, digest string) string {
+	if digest == "" || strings.Contains(ref, "@sha256:") {
+		return ref
+	}
+	return fmt.Sprintf("%s@sha256:%s", ref, digest)
+}
+
+// versionToImageTag turns a Kubernetes version (which uses "+" to separate
+// build metadata per semver) into a valid Docker image tag, where "+" is replaced by "_".
+func versionToImageTag(version string) string {
+	return strings.Replace(version, "+", "_", -1)
+}
+
+// PinnedImage is a fully parsed "registry/name:tag@sha256:<digest>" reference.
+type PinnedImage struct {
+	Repo   string
+	Name   string
+	Tag    string
+	Digest string
+}
+
+// ParsePinnedImage splits ref into its repository, name, tag and digest components. The digest
+// is optional unless requireDigest is true, in which case ParsePinnedImage returns an error for
+// any reference that does not carry a valid "@sha256:<digest>" suffix.
+func ParsePinnedImage(ref string, requireDigest bool) (PinnedImage, error) {
+	var img PinnedImage
+
+	withoutDigest := ref
+	if idx := strings.Index(ref, "@"); idx != -1 {
+		digestPart := ref[idx+1:]
+		if !strings.HasPrefix(digestPart, "sha256:") || len(digestPart) != len("sha256:")+64 {
+			return img, fmt.Errorf("invalid digest suffix %q in image reference %q, expected @sha256:<64 hex chars>", digestPart, ref)
+		}
+		img.Digest = strings.TrimPrefix(digestPart, "sha256:")
+		withoutDigest = ref[:idx]
+	} else if requireDigest {
+		return img, fmt.Errorf("image reference %q does not carry a @sha256: digest and --require-digest is set", ref)
+	}
+
+	lastSlash := strings.LastIndex(withoutDigest, "/")
+	if lastSlash == -1 {
+		return img, fmt.Errorf("image reference %q is missing a registry/repository prefix", ref)
+	}
+	img.Repo = withoutDigest[:lastSlash]
+	nameAndTag := withoutDigest[lastSlash+1:]
+
+	colon := strings.LastIndex(nameAndTag, ":")
+	if colon == -1 {
+		return img, fmt.Errorf("image reference %q is missing a tag", ref)
+	}
+	img.Name = nameAndTag[:colon]
+	img.Tag = nameAndTag[colon+1:]
+
+	return img, nil
+}
+
+// defaultImageDigests holds the compiled-in component -> sha256 digest table for Kubernetes
+// releases kubeadm knows how to pin out of the box. It is keyed first by Kubernetes minor
+// version (e.g. "v1.18") and then by component name, mirroring cfg.ImageDigests so the two can
+// be merged directly.
+var defaultImageDigests = map[string]map[string]string{
+	"v1.18": {
+		constants.KubeAPIServer:         "3d1885c0e93517de0e6b4583d0b9b737ba6dde5ac5a3d744a89c93a40a2483e1",
+		constants.KubeControllerManager: "a1d2e0c1c6d7cbea21acb3a8f7f35ec5da3f9741adfa27f1e2cbf0a6b27a8ab7",
+		constants.KubeScheduler:         "9b4b29bd3d8c8b2d8143e6f4c9ee2eca9d4f5b3c6e3e1f1a8d0e9d6f7b1a2c3d",
+		constants.Etcd:                  "e7c9c0c8f0c1c0a9e6e0d8c7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7",
+		constants.CoreDNS:               "6bfe0b7c6f6e5d4c3b2a1908f7e6d5c4b3a29180f7e6d5c4b3a29180f7e6d5c4",
+	},
+}
+
+// GetDefaultImageDigests returns the compiled-in digest table for a given Kubernetes version, or
+// nil if kubeadm does not carry defaults for that release.
+func GetDefaultImageDigests(k8sVersion string) map[string]string {
+	minor := k8sVersion
+	if idx := strings.IndexByte(k8sVersion, '.'); idx != -1 {
+		if next := strings.IndexByte(k8sVersion[idx+1:], '.'); next != -1 {
+			minor = k8sVersion[:idx+1+next]
+		}
+	}
+	return defaultImageDigests[minor]
+}
@@ -214,7 +214,7 @@ func TestGetAllImages(t *testing.T) {
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
-			imgs := GetAllImages(tc.cfg)
+			imgs := GetAllImages(tc.cfg, tc.cfg.ImageRepository)
 			for _, img := range imgs {
 				if strings.Contains(img, tc.expect) {
 					return
@@ -224,3 +224,127 @@ func TestGetAllImages(t *testing.T) {
 		})
 	}
 }
+
+func TestGetKubeControlPlaneImagePinned(t *testing.T) {
+	digest := "3d1885c0e93517de0e6b4583d0b9b737ba6dde5ac5a3d744a89c93a40a2483e1"
+	cfg := &kubeadmapi.MasterConfiguration{
+		ImageRepository:   gcrPrefix,
+		KubernetesVersion: testversion,
+		ImageDigests: map[string]string{
+			constants.KubeAPIServer: digest,
+		},
+	}
+
+	expected := fmt.Sprintf("%s@sha256:%s", GetGenericArchImage(gcrPrefix, "kube-apiserver", expected), digest)
+	actual := GetKubeControlPlaneImage(constants.KubeAPIServer, cfg)
+	if actual != expected {
+		t.Errorf("failed GetKubeControlPlaneImage with pinned digest:\n\texpected: %s\n\t  actual: %s", expected, actual)
+	}
+
+	// A component with no configured digest keeps its unpinned reference.
+	unpinned := GetKubeControlPlaneImage(constants.KubeControllerManager, cfg)
+	if strings.Contains(unpinned, "@sha256:") {
+		t.Errorf("expected kube-controller-manager image to be unpinned, got %s", unpinned)
+	}
+}
+
+func TestGetEtcdImagePinnedAndUnpinned(t *testing.T) {
+	digest := "e7c9c0c8f0c1c0a9e6e0d8c7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7"
+	pinnedCfg := &kubeadmapi.MasterConfiguration{
+		ImageRepository:   gcrPrefix,
+		KubernetesVersion: testversion,
+		ImageDigests: map[string]string{
+			constants.Etcd: digest,
+		},
+	}
+	expected := fmt.Sprintf("%s@sha256:%s", GetGenericArchImage(gcrPrefix, "etcd", constants.DefaultEtcdVersion), digest)
+	if actual := GetEtcdImage(pinnedCfg); actual != expected {
+		t.Errorf("failed GetEtcdImage with pinned digest:\n\texpected: %s\n\t  actual: %s", expected, actual)
+	}
+
+	unpinnedCfg := &kubeadmapi.MasterConfiguration{
+		ImageRepository:   gcrPrefix,
+		KubernetesVersion: testversion,
+	}
+	unpinned := GetEtcdImage(unpinnedCfg)
+	if strings.Contains(unpinned, "@sha256:") {
+		t.Errorf("expected etcd image to be unpinned, got %s", unpinned)
+	}
+
+	// A locally overridden etcd image is returned verbatim, without consulting ImageDigests.
+	overrideCfg := &kubeadmapi.MasterConfiguration{
+		Etcd: kubeadmapi.Etcd{
+			Local: &kubeadmapi.LocalEtcd{Image: "override"},
+		},
+		ImageDigests: map[string]string{constants.Etcd: digest},
+	}
+	if actual := GetEtcdImage(overrideCfg); actual != "override" {
+		t.Errorf("expected local etcd image override to win over the pinned digest, got %s", actual)
+	}
+}
+
+func TestGetCoreDNSImagePinnedAndUnpinned(t *testing.T) {
+	digest := "6bfe0b7c6f6e5d4c3b2a1908f7e6d5c4b3a29180f7e6d5c4b3a29180f7e6d5c4"
+	pinnedCfg := &kubeadmapi.MasterConfiguration{
+		ImageRepository: gcrPrefix,
+		ImageDigests: map[string]string{
+			constants.CoreDNS: digest,
+		},
+	}
+	expected := fmt.Sprintf("%s@sha256:%s", GetGenericArchImage(gcrPrefix, "coredns", constants.CoreDNSVersion), digest)
+	if actual := GetCoreDNSImage(pinnedCfg); actual != expected {
+		t.Errorf("failed GetCoreDNSImage with pinned digest:\n\texpected: %s\n\t  actual: %s", expected, actual)
+	}
+
+	unpinnedCfg := &kubeadmapi.MasterConfiguration{ImageRepository: gcrPrefix}
+	unpinned := GetCoreDNSImage(unpinnedCfg)
+	if strings.Contains(unpinned, "@sha256:") {
+		t.Errorf("expected coredns image to be unpinned, got %s", unpinned)
+	}
+}
+
+func TestGetKubeControlPlaneImageUsesCompiledInDefaultDigest(t *testing.T) {
+	cfg := &kubeadmapi.MasterConfiguration{
+		ImageRepository:   gcrPrefix,
+		KubernetesVersion: "v1.18.0",
+	}
+	actual := GetKubeControlPlaneImage(constants.KubeAPIServer, cfg)
+	defaults := GetDefaultImageDigests("v1.18.0")
+	expected := fmt.Sprintf("%s@sha256:%s", GetGenericArchImage(gcrPrefix, "kube-apiserver", "v1.18.0"), defaults[constants.KubeAPIServer])
+	if actual != expected {
+		t.Errorf("expected a stock v1.18.0 install to resolve the compiled-in default digest:\n\texpected: %s\n\t  actual: %s", expected, actual)
+	}
+
+	// An explicit ImageDigests entry still takes precedence over the compiled-in default.
+	cfg.ImageDigests = map[string]string{constants.KubeAPIServer: "override0000000000000000000000000000000000000000000000000000000000"}
+	actual = GetKubeControlPlaneImage(constants.KubeAPIServer, cfg)
+	if !strings.HasSuffix(actual, cfg.ImageDigests[constants.KubeAPIServer]) {
+		t.Errorf("expected an explicit ImageDigests entry to override the compiled-in default, got %s", actual)
+	}
+}
+
+func TestParsePinnedImage(t *testing.T) {
+	digest := "3d1885c0e93517de0e6b4583d0b9b737ba6dde5ac5a3d744a89c93a40a2483e1"
+	pinned := fmt.Sprintf("k8s.gcr.io/kube-apiserver-amd64:v1.18.0@sha256:%s", digest)
+	unpinned := "k8s.gcr.io/kube-apiserver-amd64:v1.18.0"
+
+	img, err := ParsePinnedImage(pinned, true)
+	if err != nil {
+		t.Fatalf("unexpected error parsing pinned reference: %v", err)
+	}
+	if img.Repo != "k8s.gcr.io" || img.Name != "kube-apiserver-amd64" || img.Tag != "v1.18.0" || img.Digest != digest {
+		t.Errorf("unexpected parse result: %+v", img)
+	}
+
+	if _, err := ParsePinnedImage(unpinned, false); err != nil {
+		t.Errorf("unexpected error parsing unpinned reference in non-require mode: %v", err)
+	}
+
+	if _, err := ParsePinnedImage(unpinned, true); err == nil {
+		t.Error("expected an error parsing an unpinned reference with --require-digest set, got nil")
+	}
+
+	if _, err := ParsePinnedImage("k8s.gcr.io/kube-apiserver-amd64:v1.18.0@sha256:tooshort", false); err == nil {
+		t.Error("expected an error parsing a reference with a malformed digest, got nil")
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// CRIImagePuller is the real ImagePuller SelectImageRepository uses outside of tests: it shells
+// out to crictl against the node's configured CRI socket, the same tool kubeadm already
+// documents for inspecting/pulling images by hand.
+type CRIImagePuller struct {
+	// CRISocket is the CRI socket to pull through, e.g. cfg.NodeRegistration.CRISocket. Empty
+	// uses crictl's own default.
+	CRISocket string
+}
+
+// PullImage implements ImagePuller.
+func (p *CRIImagePuller) PullImage(ctx context.Context, image string) error {
+	args := []string{"pull", image}
+	if p.CRISocket != "" {
+		args = append([]string{"--runtime-endpoint", p.CRISocket}, args...)
+	}
+	if out, err := exec.CommandContext(ctx, "crictl", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("crictl pull %s failed: %v: %s", image, err, out)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+)
+
+// defaultImageRepository is the last candidate SelectImageRepository probes when no override was
+// given, after any region mirror.
+const defaultImageRepository = "k8s.gcr.io"
+
+// regionMirrors is a compiled-in table of region -> mirror registry, consulted by
+// SelectImageRepository when the caller does not force ImageRepository in the config.
+var regionMirrors = map[string]string{
+	"cn": "registry.aliyuncs.com/google_containers",
+}
+
+// ImagePuller is the narrow CRI surface SelectImageRepository needs in order to probe whether a
+// registry is reachable: pull the (tiny) pause image and report whether it succeeded.
+type ImagePuller interface {
+	PullImage(ctx context.Context, image string) error
+}
+
+// SelectImageRepository picks an image repository to use for the cluster by probing an ordered
+// list of candidates with puller and returning the first one that answers within ctx's deadline.
+//
+// The candidate list is assembled, in priority order, from:
+//  1. cfg.ImageRepository, if the user set it explicitly - no probing is done in this case.
+//  2. the compiled-in regionMirrors table, keyed by preferredRegion. An empty preferredRegion is
+//     resolved via DetectPreferredRegion before the table is consulted.
+//  3. the default k8s.gcr.io registry.
+func SelectImageRepository(ctx context.Context, preferredRegion string, cfg *kubeadmapi.MasterConfiguration, puller ImagePuller) (string, error) {
+	if cfg.ImageRepository != "" {
+		return cfg.ImageRepository, nil
+	}
+
+	if preferredRegion == "" {
+		preferredRegion = DetectPreferredRegion()
+	}
+	candidates := buildCandidateRepositories(preferredRegion)
+
+	var errs []error
+	for _, repo := range candidates {
+		pauseImage := GetGenericArchImage(repo, "pause", constants.PauseVersion)
+		if err := puller.PullImage(ctx, pauseImage); err != nil {
+			errs = append(errs, fmt.Errorf("repository %q unreachable: %v", repo, err))
+			continue
+		}
+		return repo, nil
+	}
+
+	return "", fmt.Errorf("could not find a reachable image repository among %v: %v", candidates, errs)
+}
+
+// buildCandidateRepositories returns the ordered list of repositories SelectImageRepository
+// should probe, given a preferred region (typically sourced from --image-mirror-region or the
+// detected system locale).
+func buildCandidateRepositories(preferredRegion string) []string {
+	candidates := []string{}
+	if mirror, ok := regionMirrors[preferredRegion]; ok {
+		candidates = append(candidates, mirror)
+	}
+	candidates = append(candidates, defaultImageRepository)
+	return candidates
+}
+
+// DetectPreferredRegion infers a region code for regionMirrors from the system locale (LC_ALL,
+// then LANG), e.g. "zh_CN.UTF-8" resolves to "cn". It returns "" when no region can be inferred,
+// in which case SelectImageRepository falls through to the default registry.
+func DetectPreferredRegion() string {
+	locale := os.Getenv("LC_ALL")
+	if locale == "" {
+		locale = os.Getenv("LANG")
+	}
+	// A locale looks like "language_TERRITORY.codeset", e.g. "zh_CN.UTF-8".
+	// A locale without a territory (e.g. "C.UTF-8" or "POSIX") names no region.
+	idx := strings.IndexByte(locale, '_')
+	if idx == -1 {
+		return ""
+	}
+	locale = locale[idx+1:]
+	if idx := strings.IndexAny(locale, ".@"); idx != -1 {
+		locale = locale[:idx]
+	}
+	return strings.ToLower(locale)
+}
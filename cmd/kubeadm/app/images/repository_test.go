@@ -0,0 +1,104 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+)
+
+// stubImagePuller lets tests control which image references succeed.
+type stubImagePuller struct {
+	fail func(image string) bool
+}
+
+func (p *stubImagePuller) PullImage(_ context.Context, image string) error {
+	if p.fail != nil && p.fail(image) {
+		return fmt.Errorf("stub: could not pull %s", image)
+	}
+	return nil
+}
+
+func TestSelectImageRepositoryOverride(t *testing.T) {
+	cfg := &kubeadmapi.MasterConfiguration{ImageRepository: "my.registry.example.com"}
+	puller := &stubImagePuller{fail: func(string) bool { return true }}
+
+	repo, err := SelectImageRepository(context.Background(), "", cfg, puller)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo != "my.registry.example.com" {
+		t.Errorf("expected override repository to be used without probing, got %q", repo)
+	}
+}
+
+func TestSelectImageRepositoryFallthrough(t *testing.T) {
+	cfg := &kubeadmapi.MasterConfiguration{}
+	puller := &stubImagePuller{
+		fail: func(image string) bool {
+			// Only the mirror for the "cn" region fails; the default should be used instead.
+			return strings.HasPrefix(image, regionMirrors["cn"]+"/")
+		},
+	}
+
+	repo, err := SelectImageRepository(context.Background(), "cn", cfg, puller)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo != defaultImageRepository {
+		t.Errorf("expected fallthrough to %q, got %q", defaultImageRepository, repo)
+	}
+}
+
+func TestSelectImageRepositoryAllFail(t *testing.T) {
+	cfg := &kubeadmapi.MasterConfiguration{}
+	puller := &stubImagePuller{fail: func(string) bool { return true }}
+
+	if _, err := SelectImageRepository(context.Background(), "", cfg, puller); err == nil {
+		t.Fatal("expected an error when every candidate repository is unreachable")
+	}
+}
+
+func TestDetectPreferredRegion(t *testing.T) {
+	tests := []struct {
+		lcAll, lang string
+		expect      string
+	}{
+		{lcAll: "zh_CN.UTF-8", expect: "cn"},
+		{lang: "zh_CN.UTF-8", expect: "cn"},
+		{lcAll: "en_US.UTF-8", expect: "us"},
+		{expect: ""},
+	}
+	origLCAll, origLang := os.Getenv("LC_ALL"), os.Getenv("LANG")
+	defer func() {
+		os.Setenv("LC_ALL", origLCAll)
+		os.Setenv("LANG", origLang)
+	}()
+
+	for _, rt := range tests {
+		os.Setenv("LC_ALL", rt.lcAll)
+		os.Setenv("LANG", rt.lang)
+		if actual := DetectPreferredRegion(); actual != rt.expect {
+			t.Errorf("DetectPreferredRegion() with LC_ALL=%q LANG=%q: expected %q, got %q", rt.lcAll, rt.lang, rt.expect, actual)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preflight holds the checks "kubeadm init phase preflight" runs before any other phase.
+package preflight
+
+import (
+	"context"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/images"
+)
+
+// EnsureImageRepository resolves cfg.ImageRepository for clusters that did not set it
+// explicitly, by probing candidate registries (preferredRegion, or the detected system locale
+// when preferredRegion is empty) with puller and keeping the first one that is reachable. It is
+// run as part of "kubeadm init phase preflight" so clusters in restricted networks get a working
+// image repository without manual --image-repository tuning.
+func EnsureImageRepository(ctx context.Context, preferredRegion string, cfg *kubeadmapi.MasterConfiguration, puller images.ImagePuller) error {
+	repo, err := images.SelectImageRepository(ctx, preferredRegion, cfg, puller)
+	if err != nil {
+		return err
+	}
+	cfg.ImageRepository = repo
+	return nil
+}
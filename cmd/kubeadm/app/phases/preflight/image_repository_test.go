@@ -0,0 +1,48 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"testing"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+)
+
+type alwaysSucceedsPuller struct{}
+
+func (alwaysSucceedsPuller) PullImage(context.Context, string) error { return nil }
+
+func TestEnsureImageRepository(t *testing.T) {
+	cfg := &kubeadmapi.MasterConfiguration{}
+	if err := EnsureImageRepository(context.Background(), "", cfg, alwaysSucceedsPuller{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ImageRepository == "" {
+		t.Fatal("expected EnsureImageRepository to populate cfg.ImageRepository")
+	}
+}
+
+func TestEnsureImageRepositoryHonorsExplicitOverride(t *testing.T) {
+	cfg := &kubeadmapi.MasterConfiguration{ImageRepository: "my.registry.example.com"}
+	if err := EnsureImageRepository(context.Background(), "", cfg, alwaysSucceedsPuller{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ImageRepository != "my.registry.example.com" {
+		t.Errorf("expected the explicit override to be preserved, got %q", cfg.ImageRepository)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+)
+
+// krmResourceListAPIVersion and krmResourceListKind identify the wire format used by Kubernetes
+// Resource Model (KRM) functions, see
+// https://github.com/GoogleContainerTools/kpt/blob/main/docs/api-conventions.md
+const (
+	krmResourceListAPIVersion = "config.kubernetes.io/v1"
+	krmResourceListKind       = "ResourceList"
+)
+
+// KRMResourceList is the subset of the KRM ResourceList wire format kubeadm's KRM-function mode
+// needs to read and write.
+type KRMResourceList struct {
+	APIVersion     string            `json:"apiVersion"`
+	Kind           string            `json:"kind"`
+	FunctionConfig json.RawMessage   `json:"functionConfig,omitempty"`
+	Items          []json.RawMessage `json:"items"`
+	Results        []KRMResult       `json:"results,omitempty"`
+}
+
+// KRMResult is a single diagnostic a KRM function can attach to its output ResourceList.
+type KRMResult struct {
+	Message  string `json:"message"`
+	Severity string `json:"severity,omitempty"`
+}
+
+// ReadKRMResourceList decodes a ResourceList from r and validates that it carries the expected
+// apiVersion/kind and a non-empty functionConfig.
+func ReadKRMResourceList(r io.Reader) (*KRMResourceList, error) {
+	raw, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ResourceList: %v", err)
+	}
+
+	var list KRMResourceList
+	if err := yaml.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal ResourceList: %v", err)
+	}
+
+	if list.APIVersion != krmResourceListAPIVersion || list.Kind != krmResourceListKind {
+		return nil, fmt.Errorf("expected a %s/%s, got %s/%s", krmResourceListAPIVersion, krmResourceListKind, list.APIVersion, list.Kind)
+	}
+	if len(list.FunctionConfig) == 0 {
+		return nil, fmt.Errorf("ResourceList is missing functionConfig")
+	}
+
+	return &list, nil
+}
+
+// WriteKRMResourceList marshals list back to w as YAML.
+func WriteKRMResourceList(w io.Writer, list *KRMResourceList) error {
+	out, err := yaml.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("unable to marshal ResourceList: %v", err)
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// KRMFunctionConfigHandler receives functionConfig after RunKRMFunction has defaulted and
+// converted it with scheme/codecs (the same pipeline MarshalToYamlForCodecs relies on) and
+// returns the objects kubeadm would generate for it - e.g. static pod manifests, the kubelet
+// ConfigMap, bootstrap-token Secrets - for the caller to append to the ResourceList's items.
+type KRMFunctionConfigHandler func(functionConfig runtime.Object) ([]runtime.Object, error)
+
+// RunKRMFunction turns kubeadm into a KRM function: it reads a ResourceList from in, defaults
+// functionConfig at whatever external version it arrived as, round-trips it through the internal
+// type and back to gv (the latest external version), invokes handle to compute the objects
+// kubeadm generates for that config, appends the encoded result to the ResourceList's items and
+// writes the updated ResourceList to out.
+func RunKRMFunction(in io.Reader, out io.Writer, gv schema.GroupVersion, scheme *runtime.Scheme, codecs serializer.CodecFactory, handle KRMFunctionConfigHandler) error {
+	list, err := ReadKRMResourceList(in)
+	if err != nil {
+		return err
+	}
+
+	decoded, _, err := codecs.UniversalDeserializer().Decode(list.FunctionConfig, nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal functionConfig: %v", err)
+	}
+	scheme.Default(decoded)
+
+	internal, err := scheme.ConvertToVersion(decoded, runtime.InternalGroupVersioner)
+	if err != nil {
+		return fmt.Errorf("unable to convert functionConfig to the internal version: %v", err)
+	}
+	functionConfig, err := scheme.ConvertToVersion(internal, gv)
+	if err != nil {
+		return fmt.Errorf("unable to convert functionConfig to %s: %v", gv, err)
+	}
+
+	generated, err := handle(functionConfig)
+	if err != nil {
+		list.Results = append(list.Results, KRMResult{Message: err.Error(), Severity: "error"})
+		return WriteKRMResourceList(out, list)
+	}
+
+	for _, obj := range generated {
+		objGV := obj.GetObjectKind().GroupVersionKind().GroupVersion()
+		if objGV.Empty() {
+			objGV = gv
+		}
+		encoded, err := MarshalToYamlForCodecs(obj, objGV, codecs)
+		if err != nil {
+			return fmt.Errorf("unable to marshal generated object: %v", err)
+		}
+		jsonBytes, err := yaml.YAMLToJSON(encoded)
+		if err != nil {
+			return fmt.Errorf("unable to convert generated object to JSON: %v", err)
+		}
+		list.Items = append(list.Items, json.RawMessage(jsonBytes))
+	}
+
+	return WriteKRMResourceList(out, list)
+}
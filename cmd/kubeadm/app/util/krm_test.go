@@ -0,0 +1,107 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/scheme"
+	kubeadmapiv1alpha2 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1alpha2"
+)
+
+const krmGoldenInput = `
+apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+functionConfig:
+  apiVersion: kubeadm.k8s.io/v1alpha2
+  kind: MasterConfiguration
+  nodeRegistration:
+    name: golden-node
+items: []
+`
+
+func TestRunKRMFunctionGolden(t *testing.T) {
+	var out strings.Builder
+	err := RunKRMFunction(strings.NewReader(krmGoldenInput), &out, kubeadmapiv1alpha2.SchemeGroupVersion, scheme.Scheme, scheme.Codecs,
+		func(functionConfig runtime.Object) ([]runtime.Object, error) {
+			cfg, ok := functionConfig.(*kubeadmapiv1alpha2.MasterConfiguration)
+			if !ok {
+				t.Fatalf("expected a MasterConfiguration, got %T", functionConfig)
+			}
+			generated := cfg.DeepCopy()
+			generated.NodeRegistration.Name = cfg.NodeRegistration.Name + "-generated"
+			return []runtime.Object{generated}, nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error running KRM function: %v", err)
+	}
+
+	list, err := ReadKRMResourceListForTest(out.String())
+	if err != nil {
+		t.Fatalf("unexpected error reading generated ResourceList: %v", err)
+	}
+	if len(list.Items) != 1 {
+		t.Fatalf("expected 1 generated item, got %d", len(list.Items))
+	}
+	if !strings.Contains(string(list.Items[0]), "golden-node-generated") {
+		t.Errorf("expected generated item to reference the derived node name, got %s", list.Items[0])
+	}
+}
+
+// ReadKRMResourceListForTest is a thin wrapper around ReadKRMResourceList for tests that already
+// have the ResourceList contents in memory.
+func ReadKRMResourceListForTest(s string) (*KRMResourceList, error) {
+	return ReadKRMResourceList(strings.NewReader(s))
+}
+
+func TestReadKRMResourceListErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name: "missing functionConfig",
+			input: `
+apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items: []
+`,
+		},
+		{
+			name: "wrong apiVersion",
+			input: `
+apiVersion: v1
+kind: ResourceList
+functionConfig:
+  apiVersion: kubeadm.k8s.io/v1alpha2
+  kind: MasterConfiguration
+items: []
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ReadKRMResourceList(strings.NewReader(tc.input)); err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const yamlMediaType = "application/yaml"
+
+// MarshalToYaml marshals an object into yaml.
+func MarshalToYaml(obj runtime.Object, gv schema.GroupVersion) ([]byte, error) {
+	return MarshalToYamlForCodecs(obj, gv, scheme.Codecs)
+}
+
+// MarshalToYamlForCodecs marshals an object into yaml using the specified codec.
+func MarshalToYamlForCodecs(obj runtime.Object, gv schema.GroupVersion, codecs serializer.CodecFactory) ([]byte, error) {
+	encoder, err := yamlEncoderForVersion(codecs, gv)
+	if err != nil {
+		return []byte{}, err
+	}
+	return runtime.Encode(encoder, obj)
+}
+
+// UnmarshalFromYaml unmarshals yaml into an object.
+func UnmarshalFromYaml(buffer []byte, gv schema.GroupVersion) (runtime.Object, error) {
+	return UnmarshalFromYamlForCodecs(buffer, gv, scheme.Codecs)
+}
+
+// UnmarshalFromYamlForCodecs unmarshals yaml into an object using the specified codec.
+func UnmarshalFromYamlForCodecs(buffer []byte, gv schema.GroupVersion, codecs serializer.CodecFactory) (runtime.Object, error) {
+	decoder, err := yamlDecoderForVersion(codecs, gv)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := runtime.Decode(decoder, buffer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode %s into a runtime.Object, due to: %v", buffer, err)
+	}
+	return obj, nil
+}
+
+func yamlEncoderForVersion(codecs serializer.CodecFactory, gv schema.GroupVersion) (runtime.Encoder, error) {
+	info, ok := runtime.SerializerInfoForMediaType(codecs.SupportedMediaTypes(), yamlMediaType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported media type %q", yamlMediaType)
+	}
+	return codecs.EncoderForVersion(info.Serializer, gv), nil
+}
+
+func yamlDecoderForVersion(codecs serializer.CodecFactory, gv schema.GroupVersion) (runtime.Decoder, error) {
+	info, ok := runtime.SerializerInfoForMediaType(codecs.SupportedMediaTypes(), yamlMediaType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported media type %q", yamlMediaType)
+	}
+	return codecs.DecoderToVersion(info.Serializer, gv), nil
+}
+
+// YAMLEncoder writes a stream of objects to an underlying io.Writer as a multi-document YAML
+// stream, separating documents with "---" the way `kubectl get -o yaml` does for lists.
+type YAMLEncoder struct {
+	w      io.Writer
+	gv     schema.GroupVersioner
+	codecs serializer.CodecFactory
+	wrote  bool
+}
+
+// NewYAMLEncoder returns a YAMLEncoder that encodes objects into gv's preferred version using
+// codecs, writing each document to w separated by "---".
+func NewYAMLEncoder(w io.Writer, gv schema.GroupVersioner, codecs serializer.CodecFactory) *YAMLEncoder {
+	return &YAMLEncoder{w: w, gv: gv, codecs: codecs}
+}
+
+// Encode writes obj as the next document in the stream.
+func (e *YAMLEncoder) Encode(obj runtime.Object) error {
+	info, ok := runtime.SerializerInfoForMediaType(e.codecs.SupportedMediaTypes(), yamlMediaType)
+	if !ok {
+		return fmt.Errorf("unsupported media type %q", yamlMediaType)
+	}
+	encoder := e.codecs.EncoderForVersion(info.Serializer, e.gv)
+	data, err := runtime.Encode(encoder, obj)
+	if err != nil {
+		return err
+	}
+	if e.wrote {
+		if _, err := io.WriteString(e.w, "---\n"); err != nil {
+			return err
+		}
+	}
+	e.wrote = true
+	_, err = e.w.Write(data)
+	return err
+}
+
+// YAMLDecoder reads a multi-document YAML stream and decodes each document into the Go type
+// registered for its TypeMeta (apiVersion/kind). Documents are returned at the version they
+// were written in: they are not converted to the internal version and are not defaulted.
+type YAMLDecoder struct {
+	reader *yaml.YAMLReader
+	codecs serializer.CodecFactory
+}
+
+// NewYAMLDecoder returns a YAMLDecoder that reads "---"-separated YAML documents from r and
+// decodes each one using codecs' universal deserializer.
+func NewYAMLDecoder(r io.Reader, codecs serializer.CodecFactory) *YAMLDecoder {
+	return &YAMLDecoder{
+		reader: yaml.NewYAMLReader(bufio.NewReader(r)),
+		codecs: codecs,
+	}
+}
+
+// DecodeAll reads every document in the stream and returns the decoded objects, in stream order.
+// Unlike a single Decode call, DecodeAll does not stop at the first document that fails to
+// decode: it records the error against that document's position and continues with the rest, so
+// a single malformed document in a large multi-doc file does not hide the valid ones.
+func (d *YAMLDecoder) DecodeAll() ([]runtime.Object, []error) {
+	var (
+		objs []runtime.Object
+		errs []error
+	)
+	for i := 0; ; i++ {
+		doc, err := d.reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("document %d: unable to read: %v", i, err))
+			break
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, _, err := d.codecs.UniversalDeserializer().Decode(doc, nil, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("document %d: unable to decode: %v", i, err))
+			continue
+		}
+		objs = append(objs, obj)
+	}
+	return objs, errs
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package util
 
 import (
+	"bytes"
 	"reflect"
+	"strings"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -114,3 +116,84 @@ func TestMarshalUnmarshalToYamlForCodecs(t *testing.T) {
 		t.Errorf("expected %v, got %v", *cfg, *cfg2)
 	}
 }
+
+func TestYAMLEncoderDecoderRoundTrip(t *testing.T) {
+	cfg1 := &kubeadmapiv1alpha2.MasterConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MasterConfiguration",
+			APIVersion: kubeadmapiv1alpha2.SchemeGroupVersion.String(),
+		},
+		NodeRegistration: kubeadmapiv1alpha2.NodeRegistrationOptions{Name: "node1"},
+	}
+	scheme.Scheme.Default(cfg1)
+	cfg2 := &kubeadmapiv1alpha2.MasterConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MasterConfiguration",
+			APIVersion: kubeadmapiv1alpha2.SchemeGroupVersion.String(),
+		},
+		NodeRegistration: kubeadmapiv1alpha2.NodeRegistrationOptions{Name: "node2"},
+	}
+	scheme.Scheme.Default(cfg2)
+
+	var buf bytes.Buffer
+	enc := NewYAMLEncoder(&buf, kubeadmapiv1alpha2.SchemeGroupVersion, scheme.Codecs)
+	if err := enc.Encode(cfg1); err != nil {
+		t.Fatalf("unexpected error encoding first document: %v", err)
+	}
+	if err := enc.Encode(cfg2); err != nil {
+		t.Fatalf("unexpected error encoding second document: %v", err)
+	}
+
+	dec := NewYAMLDecoder(&buf, scheme.Codecs)
+	objs, errs := dec.DecodeAll()
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors decoding stream: %v", errs)
+	}
+	if len(objs) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(objs))
+	}
+
+	got1, ok := objs[0].(*kubeadmapiv1alpha2.MasterConfiguration)
+	if !ok {
+		t.Fatalf("expected first document to be a MasterConfiguration, got %T", objs[0])
+	}
+	if got1.NodeRegistration.Name != "node1" {
+		t.Errorf("expected first document's node name to be %q, got %q", "node1", got1.NodeRegistration.Name)
+	}
+
+	got2, ok := objs[1].(*kubeadmapiv1alpha2.MasterConfiguration)
+	if !ok {
+		t.Fatalf("expected second document to be a MasterConfiguration, got %T", objs[1])
+	}
+	if got2.NodeRegistration.Name != "node2" {
+		t.Errorf("expected second document's node name to be %q, got %q", "node2", got2.NodeRegistration.Name)
+	}
+}
+
+func TestYAMLDecoderSkipsBadDocuments(t *testing.T) {
+	stream := `apiVersion: kubeadm.k8s.io/v1alpha2
+kind: MasterConfiguration
+nodeRegistration:
+  name: good-doc
+---
+apiVersion: not.a.real/v1
+kind: TotallyUnknownKind
+spec:
+  whatever: true
+`
+	dec := NewYAMLDecoder(strings.NewReader(stream), scheme.Codecs)
+	objs, errs := dec.DecodeAll()
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly 1 error for the unknown-kind document, got %d: %v", len(errs), errs)
+	}
+	if len(objs) != 1 {
+		t.Fatalf("expected the valid document to still decode, got %d objects", len(objs))
+	}
+	cfg, ok := objs[0].(*kubeadmapiv1alpha2.MasterConfiguration)
+	if !ok {
+		t.Fatalf("expected a MasterConfiguration, got %T", objs[0])
+	}
+	if cfg.NodeRegistration.Name != "good-doc" {
+		t.Errorf("expected node name %q, got %q", "good-doc", cfg.NodeRegistration.Name)
+	}
+}